@@ -0,0 +1,108 @@
+package aipclient
+
+import (
+	"net/http"
+	"time"
+)
+
+// TokenProvider 用于获取Bearer Token，调用方可以自行实现该接口以接入OAuth2客户端凭证、
+// 静态Token、刷新Token等鉴权方式，替代内置的用户名密码认证
+type TokenProvider interface {
+	// Token 返回当前可用的Token及其过期时间；expiresAt为零值表示该Token没有已知的过期时间
+	Token() (token string, expiresAt time.Time, err error)
+}
+
+// basicAuthProvider 通过用户名和密码向认证接口换取Bearer Token，用于兼容旧版构造函数的鉴权方式
+type basicAuthProvider struct {
+	client   *http.Client
+	authURL  string
+	username string
+	password string
+}
+
+func newBasicAuthProvider(client *http.Client, authURL, username, password string) *basicAuthProvider {
+	return &basicAuthProvider{
+		client:   client,
+		authURL:  authURL,
+		username: username,
+		password: password,
+	}
+}
+
+// Token 实现TokenProvider接口，基础认证方式下Token没有已知的过期时间，需要依赖401触发被动刷新
+func (p *basicAuthProvider) Token() (string, time.Time, error) {
+	token, err := authenticate(p.client, p.authURL, p.username, p.password)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, time.Time{}, nil
+}
+
+// noopTokenProvider 是未配置TokenProvider时的默认实现，不携带任何鉴权信息，
+// 适用于不需要Bearer Token的接口
+type noopTokenProvider struct{}
+
+func (noopTokenProvider) Token() (string, time.Time, error) {
+	return "", time.Time{}, nil
+}
+
+// tokenExpiryLeeway 是Token过期前提前刷新的安全余量，避免请求发出时Token恰好失效
+const tokenExpiryLeeway = 30 * time.Second
+
+// currentToken 返回可直接使用的Token：如果已知的过期时间即将到达，则提前进行一次主动刷新
+func (c *HTTPClient) currentToken() (string, error) {
+	c.mu.Lock()
+	token := c.token
+	expiry := c.tokenExpiry
+	c.mu.Unlock()
+
+	if token != "" && (expiry.IsZero() || time.Now().Add(tokenExpiryLeeway).Before(expiry)) {
+		return token, nil
+	}
+	return c.refreshToken()
+}
+
+// refreshToken 从TokenProvider获取新的Token并更新缓存。并发场景下多个请求同时触发刷新时，
+// 只有第一个请求会真正调用TokenProvider，其余请求复用同一次刷新结果（单飞模式）
+func (c *HTTPClient) refreshToken() (string, error) {
+	c.refreshMu.Lock()
+	if ch := c.refreshing; ch != nil {
+		c.refreshMu.Unlock()
+		<-ch
+		c.mu.Lock()
+		token, err := c.token, c.refreshErr
+		c.mu.Unlock()
+		return token, err
+	}
+
+	ch := make(chan struct{})
+	c.refreshing = ch
+	c.refreshMu.Unlock()
+
+	token, expiresAt, err := c.tokenProvider.Token()
+
+	c.mu.Lock()
+	if err == nil {
+		c.token = token
+		c.tokenExpiry = expiresAt
+	}
+	c.refreshErr = err
+	c.mu.Unlock()
+
+	c.refreshMu.Lock()
+	c.refreshing = nil
+	c.refreshMu.Unlock()
+	close(ch)
+
+	return token, err
+}
+
+// isUnauthorized 判断响应状态码是否属于需要刷新Token并重试的"未授权"状态码集合
+func (c *HTTPClient) isUnauthorized(statusCode int) bool {
+	for _, code := range c.unauthorizedCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
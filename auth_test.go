@@ -0,0 +1,93 @@
+package aipclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTokenProvider模拟一次较慢的Token获取过程，用于验证并发刷新会被合并为一次调用
+type countingTokenProvider struct {
+	calls int32
+}
+
+func (p *countingTokenProvider) Token() (string, time.Time, error) {
+	n := atomic.AddInt32(&p.calls, 1)
+	time.Sleep(20 * time.Millisecond)
+	return fmt.Sprintf("token-%d", n), time.Time{}, nil
+}
+
+func TestRefreshTokenCoalescesConcurrentCallers(t *testing.T) {
+	provider := &countingTokenProvider{}
+	c := &HTTPClient{tokenProvider: provider}
+
+	const goroutines = 20
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := c.refreshToken(); err != nil {
+				t.Errorf("refreshToken返回了意外的错误: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&provider.calls); got != 1 {
+		t.Fatalf("期望并发刷新合并为1次TokenProvider调用，实际调用了%d次", got)
+	}
+}
+
+func TestWithUnauthorizedCodesOverridesDefault(t *testing.T) {
+	c := &HTTPClient{unauthorizedCodes: []int{401}}
+	WithUnauthorizedCodes([]int{401, 403})(c)
+
+	if !c.isUnauthorized(403) {
+		t.Fatal("配置403为未授权状态码后，isUnauthorized(403)应返回true")
+	}
+	if !c.isUnauthorized(401) {
+		t.Fatal("isUnauthorized(401)应返回true")
+	}
+	if c.isUnauthorized(404) {
+		t.Fatal("isUnauthorized(404)应返回false")
+	}
+}
+
+// TestNewHTTPClientAuthUsesCallerProvidedHTTPClient 验证NewHTTPClient的基础认证在额外传入
+// WithHTTPClient时，认证请求也会通过调用方提供的http.Client发出，而不是停留在构造函数内部
+// 临时创建的那个http.Client上（否则认证请求会用着一套不同的TLS信任、代理等配置）
+func TestNewHTTPClientAuthUsesCallerProvidedHTTPClient(t *testing.T) {
+	authServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"token":"tok"}`))
+	}))
+	defer authServer.Close()
+
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer apiServer.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(authServer.Certificate())
+	pool.AddCert(apiServer.Certificate())
+	customClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+
+	client, err := NewHTTPClient(apiServer.URL, authServer.URL, "user", "pass", WithHTTPClient(customClient))
+	if err != nil {
+		t.Fatalf("创建客户端失败，认证请求应当信任调用方http.Client所信任的证书: %v", err)
+	}
+
+	var v struct{}
+	if _, err := client.Get("/", nil, &v); err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+}
@@ -2,13 +2,14 @@ package aipclient
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 )
 
@@ -18,37 +19,96 @@ type ClientInterface interface {
 	Post(endpoint string, jsonStr []byte, v interface{}) (int, error)
 	Put(endpoint string, jsonStr []byte, v interface{}) (int, error)
 	Delete(endpoint string, params map[string]string, v interface{}) (int, error)
+	PostFile(endpoint, formName, fileName, filePath string, fields map[string]string, v interface{}) (int, error)
+	PostMultipart(endpoint string, parts []MultipartPart, v interface{}) (int, error)
+	GetCtx(ctx context.Context, endpoint string, params map[string]string, v interface{}) (int, error)
+	PostCtx(ctx context.Context, endpoint string, jsonStr []byte, v interface{}) (int, error)
+	PutCtx(ctx context.Context, endpoint string, jsonStr []byte, v interface{}) (int, error)
+	DeleteCtx(ctx context.Context, endpoint string, params map[string]string, v interface{}) (int, error)
 }
 
 // HTTPClient 自定义的HTTP客户端
 type HTTPClient struct {
 	client  *http.Client
 	baseURL string
-	token   string
+
+	tokenProvider     TokenProvider
+	unauthorizedCodes []int
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+	refreshErr  error
+
+	refreshMu  sync.Mutex
+	refreshing chan struct{}
+
+	debug  bool
+	logger Logger
+
+	baseHeaders      http.Header
+	maxRetries       int
+	backoff          BackoffFunc
+	retryStatusCodes []int
 }
 
-var tr = &http.Transport{
-	TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+// NewHTTPClient 创建一个新的HTTP客户端实例，并通过用户名和密码进行认证获取Bearer Token。
+// 这是历史上一直存在的构造函数签名，为了不破坏现有调用方而保留；opts为可选的追加配置
+// （超时、TLS、重试、代理等），完全可以不传。需要用TokenProvider替换用户名密码鉴权、
+// 或者完全不需要鉴权的场景，请使用NewHTTPClientWithOptions
+func NewHTTPClient(baseURL, authURL, username, password string, opts ...Option) (ClientInterface, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	provider := newBasicAuthProvider(client, authURL, username, password)
+
+	allOpts := append([]Option{WithHTTPClient(client), WithTokenProvider(provider)}, opts...)
+	return NewHTTPClientWithOptions(baseURL, allOpts...)
 }
 
-// NewHTTPClient 创建一个新的HTTP客户端实例，并通过用户名和密码进行认证获取Bearer Token
-func NewHTTPClient(baseURL, authURL, username, password string) (ClientInterface, error) {
-	client := &http.Client{
-		Timeout:   10 * time.Second,
-		Transport: tr,
+// NewHTTPClientWithOptions 创建一个新的HTTP客户端实例，可以通过Option定制超时、TLS、重试、代理、
+// 鉴权方式等行为。默认使用标准库的证书校验（此前版本中Transport固定开启InsecureSkipVerify是一个
+// 安全隐患），如确有需要可以通过WithTLSConfig显式放宽。默认不携带鉴权信息，可通过WithTokenProvider接入
+func NewHTTPClientWithOptions(baseURL string, opts ...Option) (ClientInterface, error) {
+	c := &HTTPClient{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		baseURL:           baseURL,
+		tokenProvider:     noopTokenProvider{},
+		unauthorizedCodes: []int{http.StatusUnauthorized},
+		logger:            defaultLogger{},
+		retryStatusCodes:  defaultRetryStatusCodes,
+		backoff:           ExponentialBackoff(200 * time.Millisecond),
 	}
 
-	// 进行认证获取Bearer Token
-	token, err := authenticate(client, authURL, username, password)
-	if err != nil {
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// opts中的WithHTTPClient可能晚于basicAuthProvider的创建生效（例如NewHTTPClient的
+	// 调用方额外传入了自己的http.Client），这里以应用完全部opts后最终确定的c.client为准，
+	// 重新绑定basicAuthProvider使用的client，避免认证请求仍然走着一个过时的http.Client/Transport
+	if provider, ok := c.tokenProvider.(*basicAuthProvider); ok {
+		provider.client = c.client
+	}
+
+	if _, err := c.refreshToken(); err != nil {
 		return nil, err
 	}
 
-	return &HTTPClient{
-		client:  client,
-		baseURL: baseURL,
-		token:   token,
-	}, nil
+	return c, nil
+}
+
+// NewHTTPClientWithProvider 创建一个新的HTTP客户端实例，Token的获取与刷新交由调用方提供的TokenProvider完成，
+// 从而可以接入OAuth2客户端凭证、静态Token、刷新Token等自定义鉴权流程，而不必使用内置的用户名密码认证。
+// client为nil时使用默认配置
+func NewHTTPClientWithProvider(baseURL string, client *http.Client, provider TokenProvider, opts ...Option) (ClientInterface, error) {
+	allOpts := []Option{WithTokenProvider(provider)}
+	if client != nil {
+		allOpts = append(allOpts, WithHTTPClient(client))
+	}
+	allOpts = append(allOpts, opts...)
+
+	return NewHTTPClientWithOptions(baseURL, allOpts...)
 }
 
 // authenticate 用于通过用户名和密码进行认证，并获取Bearer Token
@@ -96,35 +156,138 @@ func authenticate(client *http.Client, authURL, username, password string) (stri
 	return token, nil
 }
 
-// doRequest 发送HTTP请求并将响应解析为指定的数据结构
+// doRequest 发送HTTP请求并将响应解析为指定的数据结构。当响应状态码属于未授权状态码集合时，
+// 会刷新Token并重试一次原始请求；幂等方法（GET/PUT/DELETE）命中可重试状态码时，还会按配置的
+// 重试次数和退避策略重试。重试时请求体通过req.GetBody重新获取：对于支持GetBody的请求
+// （例如PostMultipart会直接从磁盘重新构建multipart流），不会把请求体缓存进内存；只有在
+// req.GetBody为空时，才退化为读取并缓存一次请求体，以便后续重试能够重新发送
 func (c *HTTPClient) doRequest(req *http.Request, v interface{}) (int, error) {
-	// 设置请求头
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	getBody := req.GetBody
+	if req.Body != nil && getBody == nil {
+		bodyBytes, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return 0, fmt.Errorf("读取请求体失败: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		getBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+		}
+	}
+
+	baseHeader := req.Header.Clone()
+	idempotent := isIdempotentMethod(req.Method)
+
+	var statusCode int
+	var header http.Header
+	var body []byte
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.Clone(req.Context())
+		attemptReq.Header = baseHeader.Clone()
+		if attempt > 0 && getBody != nil {
+			rc, err := getBody()
+			if err != nil {
+				return 0, fmt.Errorf("重建请求体失败: %w", err)
+			}
+			attemptReq.Body = rc
+		}
+		for key, values := range c.baseHeaders {
+			for i, value := range values {
+				if i == 0 {
+					attemptReq.Header.Set(key, value)
+				} else {
+					attemptReq.Header.Add(key, value)
+				}
+			}
+		}
+
+		token, err := c.currentToken()
+		if err != nil {
+			return 0, fmt.Errorf("获取Token失败: %w", err)
+		}
+		if token != "" {
+			attemptReq.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		statusCode, header, body, err = c.send(attemptReq)
+		if err != nil {
+			return 0, err
+		}
+
+		if c.isUnauthorized(statusCode) {
+			if newToken, refreshErr := c.refreshToken(); refreshErr == nil {
+				retryReq := attemptReq.Clone(req.Context())
+				if getBody != nil {
+					if rc, err := getBody(); err == nil {
+						retryReq.Body = rc
+					}
+				}
+				if newToken != "" {
+					retryReq.Header.Set("Authorization", "Bearer "+newToken)
+				}
+				if retryStatus, retryHeader, retryBody, retryErr := c.send(retryReq); retryErr == nil {
+					statusCode, header, body = retryStatus, retryHeader, retryBody
+				}
+			}
+		}
+
+		if !idempotent || attempt >= c.maxRetries || !c.isRetryable(statusCode) {
+			break
+		}
+
+		time.Sleep(c.retryWait(attempt+1, header))
+	}
+
+	if !isSuccessStatus(statusCode) {
+		return statusCode, &RequestError{
+			StatusCode: statusCode,
+			Status:     http.StatusText(statusCode),
+			Body:       body,
+			Header:     header,
+			URL:        req.URL.String(),
+		}
+	}
+
+	if err := decodeResponse(statusCode, body, v); err != nil {
+		return statusCode, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	return statusCode, nil
+}
+
+// send 执行一次HTTP请求并读取完整的响应体，开启Debug时会打印请求和响应的Dump信息
+func (c *HTTPClient) send(req *http.Request) (int, http.Header, []byte, error) {
+	if c.debug {
+		c.dumpRequest(req)
+	}
+	start := time.Now()
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("请求失败: %w", err)
+		return 0, nil, nil, fmt.Errorf("请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, fmt.Errorf("读取响应失败: %w", err)
+		return 0, nil, nil, fmt.Errorf("读取响应失败: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return resp.StatusCode, fmt.Errorf("请求失败，状态码: %d，响应: %s", resp.StatusCode, body)
-	}
-
-	if err := json.Unmarshal(body, v); err != nil {
-		return resp.StatusCode, fmt.Errorf("解析响应失败: %w", err)
+	if c.debug {
+		c.dumpResponse(req, resp, body, time.Since(start))
 	}
 
-	return resp.StatusCode, nil
+	return resp.StatusCode, resp.Header, body, nil
 }
 
 // Get 发送HTTP GET请求并将响应解析为指定的数据结构
 func (c *HTTPClient) Get(endpoint string, params map[string]string, v interface{}) (int, error) {
+	return c.GetCtx(context.Background(), endpoint, params, v)
+}
+
+// GetCtx 发送HTTP GET请求并将响应解析为指定的数据结构，ctx用于控制请求的取消、超时和链路追踪
+func (c *HTTPClient) GetCtx(ctx context.Context, endpoint string, params map[string]string, v interface{}) (int, error) {
 	u, err := url.Parse(c.baseURL + endpoint)
 	if err != nil {
 		return 0, fmt.Errorf("解析URL失败: %w", err)
@@ -136,7 +299,7 @@ func (c *HTTPClient) Get(endpoint string, params map[string]string, v interface{
 	}
 	u.RawQuery = query.Encode()
 
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return 0, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -145,8 +308,13 @@ func (c *HTTPClient) Get(endpoint string, params map[string]string, v interface{
 
 // Post 发送HTTP POST请求并将响应解析为指定的数据结构
 func (c *HTTPClient) Post(endpoint string, jsonStr []byte, v interface{}) (int, error) {
+	return c.PostCtx(context.Background(), endpoint, jsonStr, v)
+}
+
+// PostCtx 发送HTTP POST请求并将响应解析为指定的数据结构，ctx用于控制请求的取消、超时和链路追踪
+func (c *HTTPClient) PostCtx(ctx context.Context, endpoint string, jsonStr []byte, v interface{}) (int, error) {
 	u := c.baseURL + endpoint
-	req, err := http.NewRequest("POST", u, bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewBuffer(jsonStr))
 	if err != nil {
 		return 0, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -156,8 +324,13 @@ func (c *HTTPClient) Post(endpoint string, jsonStr []byte, v interface{}) (int,
 
 // Put 发送HTTP PUT请求并将响应解析为指定的数据结构
 func (c *HTTPClient) Put(endpoint string, jsonStr []byte, v interface{}) (int, error) {
+	return c.PutCtx(context.Background(), endpoint, jsonStr, v)
+}
+
+// PutCtx 发送HTTP PUT请求并将响应解析为指定的数据结构，ctx用于控制请求的取消、超时和链路追踪
+func (c *HTTPClient) PutCtx(ctx context.Context, endpoint string, jsonStr []byte, v interface{}) (int, error) {
 	u := c.baseURL + endpoint
-	req, err := http.NewRequest("PUT", u, bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(ctx, "PUT", u, bytes.NewBuffer(jsonStr))
 	if err != nil {
 		return 0, fmt.Errorf("创建请求失败: %w", err)
 	}
@@ -167,6 +340,11 @@ func (c *HTTPClient) Put(endpoint string, jsonStr []byte, v interface{}) (int, e
 
 // Delete 发送HTTP DELETE请求并将响应解析为指定的数据结构
 func (c *HTTPClient) Delete(endpoint string, params map[string]string, v interface{}) (int, error) {
+	return c.DeleteCtx(context.Background(), endpoint, params, v)
+}
+
+// DeleteCtx 发送HTTP DELETE请求并将响应解析为指定的数据结构，ctx用于控制请求的取消、超时和链路追踪
+func (c *HTTPClient) DeleteCtx(ctx context.Context, endpoint string, params map[string]string, v interface{}) (int, error) {
 	parse, err := url.Parse(c.baseURL + endpoint)
 	if err != nil {
 		return 0, fmt.Errorf("解析URL失败: %w", err)
@@ -178,7 +356,7 @@ func (c *HTTPClient) Delete(endpoint string, params map[string]string, v interfa
 	}
 	parse.RawQuery = query.Encode()
 
-	req, err := http.NewRequest("DELETE", parse.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", parse.String(), nil)
 	if err != nil {
 		return 0, fmt.Errorf("创建请求失败: %w", err)
 	}
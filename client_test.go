@@ -0,0 +1,46 @@
+package aipclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetCtxReturnsDeadlineExceededOnSlowServer(t *testing.T) {
+	unblock := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer func() {
+		close(unblock)
+		server.Close()
+	}()
+
+	client, err := NewHTTPClientWithOptions(server.URL)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	var v struct{}
+	_, err = client.GetCtx(ctx, "/slow", nil, &v)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("期望请求因超时返回错误，实际没有错误")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("期望错误链中包含context.DeadlineExceeded，实际err: %v", err)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("期望请求在超时后很快返回，实际耗时: %s", elapsed)
+	}
+}
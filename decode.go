@@ -0,0 +1,33 @@
+package aipclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// ResponseDecoder 允许调用方自定义响应体的解析方式，替代默认的json.Unmarshal，
+// 适用于XML、protobuf等非JSON响应，或者需要结合状态码做特殊处理的解析逻辑
+type ResponseDecoder interface {
+	DecodeResponse(statusCode int, body []byte) error
+}
+
+// decodeResponse 将响应体写入v：v实现了ResponseDecoder时交由其自行解析；v实现了io.Writer时
+// （例如*os.File或*bytes.Buffer）直接把响应体拷贝进去，用于大文件下载等非JSON场景；
+// 其余情况按照默认行为以JSON解析。v为nil或响应体为空（例如201/204等无响应体的成功状态码）时
+// 直接返回，避免把空字节交给json.Unmarshal产生"unexpected end of JSON input"之类的误报错误
+func decodeResponse(statusCode int, body []byte, v interface{}) error {
+	if v == nil || len(body) == 0 {
+		return nil
+	}
+
+	switch dst := v.(type) {
+	case ResponseDecoder:
+		return dst.DecodeResponse(statusCode, body)
+	case io.Writer:
+		_, err := io.Copy(dst, bytes.NewReader(body))
+		return err
+	default:
+		return json.Unmarshal(body, v)
+	}
+}
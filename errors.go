@@ -0,0 +1,66 @@
+package aipclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RequestError 表示一次HTTP请求收到了非2xx的响应，调用方可以据此判断具体的错误类型，
+// 而不必再解析错误信息字符串
+type RequestError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+	Header     http.Header
+	URL        string
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("请求失败，状态码: %d，URL: %s，响应: %s", e.StatusCode, e.URL, e.Body)
+}
+
+// Decode 将错误响应体解析到调用方提供的结构体中，用于处理形如{"code":...,"message":...}的
+// API自定义错误格式
+func (e *RequestError) Decode(v interface{}) error {
+	return json.Unmarshal(e.Body, v)
+}
+
+// isSuccessStatus 判断状态码是否属于2xx成功范围
+func isSuccessStatus(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
+
+// IsNotFound 判断err是否是状态码404的RequestError
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsUnauthorized 判断err是否是状态码401的RequestError
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsRetryable 判断err是否是状态码属于默认可重试状态码集合（429、502、503、504）的RequestError
+func IsRetryable(err error) bool {
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		return false
+	}
+	for _, code := range defaultRetryStatusCodes {
+		if reqErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// hasStatusCode 判断err是否是指定状态码的RequestError
+func hasStatusCode(err error, statusCode int) bool {
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) {
+		return false
+	}
+	return reqErr.StatusCode == statusCode
+}
@@ -0,0 +1,75 @@
+package aipclient
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+)
+
+// Logger 是调试日志的输出接口，便于接入业务方已有的日志库
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// defaultLogger 是未指定Logger时使用的默认实现，基于标准库log包输出
+type defaultLogger struct{}
+
+func (defaultLogger) Printf(format string, args ...interface{}) {
+	log.Printf(format, args...)
+}
+
+// WithDebug 开启调试模式：每次请求都会通过logger打印请求和响应的Dump信息（方法、URL、状态码、耗时），
+// Authorization、Cookie等敏感请求头会被脱敏，multipart请求体不会被打印。logger为nil时使用默认实现
+func WithDebug(logger Logger) Option {
+	return func(c *HTTPClient) {
+		c.debug = true
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// sensitiveHeaders 是Dump时需要脱敏的请求头
+var sensitiveHeaders = []string{"Authorization", "Cookie"}
+
+// redactHeaders 将Dump内容中敏感请求头的值替换为占位符，避免Token、Cookie等信息写入日志
+func redactHeaders(dump []byte) []byte {
+	lines := bytes.Split(dump, []byte("\r\n"))
+	for i, line := range lines {
+		for _, header := range sensitiveHeaders {
+			prefix := header + ":"
+			if bytes.HasPrefix(line, []byte(prefix)) {
+				lines[i] = []byte(prefix + " [REDACTED]")
+			}
+		}
+	}
+	return bytes.Join(lines, []byte("\r\n"))
+}
+
+// dumpRequest 打印请求的Dump信息。multipart请求体不会被打印，避免把文件内容灌进日志
+func (c *HTTPClient) dumpRequest(req *http.Request) {
+	includeBody := !strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/")
+
+	dump, err := httputil.DumpRequestOut(req, includeBody)
+	if err != nil {
+		c.logger.Printf("[apiclient] 请求Dump失败: %v", err)
+		return
+	}
+	c.logger.Printf("[apiclient] 请求:\n%s", redactHeaders(dump))
+}
+
+// dumpResponse 打印响应的Dump信息，包括方法、URL、状态码和耗时
+func (c *HTTPClient) dumpResponse(req *http.Request, resp *http.Response, body []byte, elapsed time.Duration) {
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		c.logger.Printf("[apiclient] 响应Dump失败: %v", err)
+		return
+	}
+	c.logger.Printf("[apiclient] %s %s -> %d 耗时 %s\n%s", req.Method, req.URL.String(), resp.StatusCode, elapsed, redactHeaders(dump))
+}
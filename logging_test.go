@@ -0,0 +1,96 @@
+package aipclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// captureLogger 把Printf输出的内容收集起来，便于测试断言Dump内容
+type captureLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *captureLogger) Printf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func (l *captureLogger) dump() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.lines, "\n")
+}
+
+func TestWithDebugRedactsSensitiveHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	logger := &captureLogger{}
+	client, err := NewHTTPClientWithOptions(server.URL,
+		WithTokenProvider(staticTokenProvider("secret-token")),
+		WithBaseHeaders(http.Header{"Cookie": []string{"session=secret-cookie"}}),
+		WithDebug(logger),
+	)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	if _, err := client.Get("/", nil, &v); err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+
+	dump := logger.dump()
+	if strings.Contains(dump, "secret-token") {
+		t.Fatalf("Dump内容中不应包含Token明文，实际: %s", dump)
+	}
+	if strings.Contains(dump, "secret-cookie") {
+		t.Fatalf("Dump内容中不应包含Cookie明文，实际: %s", dump)
+	}
+	if !strings.Contains(dump, "[REDACTED]") {
+		t.Fatalf("Dump内容中应包含[REDACTED]占位符，实际: %s", dump)
+	}
+}
+
+func TestWithDebugSkipsMultipartBody(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "secret.txt")
+	content := []byte("top-secret-file-content")
+	if err := os.WriteFile(filePath, content, 0o600); err != nil {
+		t.Fatalf("写入测试文件失败: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	logger := &captureLogger{}
+	client, err := NewHTTPClientWithOptions(server.URL, WithDebug(logger))
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	if _, err := client.PostFile("/upload", "file", "secret.txt", filePath, nil, &v); err != nil {
+		t.Fatalf("上传文件失败: %v", err)
+	}
+
+	if strings.Contains(logger.dump(), string(content)) {
+		t.Fatal("Dump内容中不应包含multipart文件内容")
+	}
+}
@@ -0,0 +1,95 @@
+package aipclient
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+)
+
+// MultipartPart 描述multipart/form-data请求体中的一个部分。FileName非空时表示这是一个文件部分，
+// 内容从FilePath指向的文件中读取；FileName为空时表示这是一个普通表单字段，内容取自Value
+type MultipartPart struct {
+	FieldName string
+	FileName  string
+	FilePath  string
+	Value     string
+}
+
+// PostFile 将指定路径的文件以及附带的普通表单字段一起通过multipart/form-data上传
+func (c *HTTPClient) PostFile(endpoint, formName, fileName, filePath string, fields map[string]string, v interface{}) (int, error) {
+	parts := make([]MultipartPart, 0, len(fields)+1)
+	parts = append(parts, MultipartPart{FieldName: formName, FileName: fileName, FilePath: filePath})
+	for key, value := range fields {
+		parts = append(parts, MultipartPart{FieldName: key, Value: value})
+	}
+	return c.PostMultipart(endpoint, parts, v)
+}
+
+// PostMultipart 发送一个multipart/form-data请求。文件内容通过io.Copy直接从磁盘流式写入请求体，
+// 不会先把整个文件读入内存，适合上传较大的文件。请求体通过req.GetBody暴露为可重新构建的流，
+// 这样doRequest在401重试时会重新从磁盘打开文件，而不是把已读取的内容缓存在内存里。
+// 请求方法固定为POST，不属于幂等方法，因此不会触发按状态码的幂等重试
+func (c *HTTPClient) PostMultipart(endpoint string, parts []MultipartPart, v interface{}) (int, error) {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	buildBody := func() (io.ReadCloser, error) {
+		pr, pw := io.Pipe()
+		writer := multipart.NewWriter(pw)
+		if err := writer.SetBoundary(boundary); err != nil {
+			return nil, fmt.Errorf("设置multipart边界失败: %w", err)
+		}
+
+		go func() {
+			defer pw.Close()
+			defer writer.Close()
+
+			for _, part := range parts {
+				if part.FileName == "" {
+					if err := writer.WriteField(part.FieldName, part.Value); err != nil {
+						pw.CloseWithError(fmt.Errorf("写入表单字段失败: %w", err))
+						return
+					}
+					continue
+				}
+
+				file, err := os.Open(part.FilePath)
+				if err != nil {
+					pw.CloseWithError(fmt.Errorf("打开文件失败: %w", err))
+					return
+				}
+
+				fw, err := writer.CreateFormFile(part.FieldName, part.FileName)
+				if err != nil {
+					file.Close()
+					pw.CloseWithError(fmt.Errorf("创建表单文件失败: %w", err))
+					return
+				}
+
+				if _, err := io.Copy(fw, file); err != nil {
+					file.Close()
+					pw.CloseWithError(fmt.Errorf("写入文件内容失败: %w", err))
+					return
+				}
+				file.Close()
+			}
+		}()
+
+		return pr, nil
+	}
+
+	body, err := buildBody()
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("POST", c.baseURL+endpoint, body)
+	if err != nil {
+		return 0, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
+	req.GetBody = buildBody
+
+	return c.doRequest(req, v)
+}
@@ -0,0 +1,78 @@
+package aipclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPostMultipartRetriesByReopeningFile(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "upload.txt")
+	content := []byte("hello multipart streaming")
+	if err := os.WriteFile(filePath, content, 0o600); err != nil {
+		t.Fatalf("写入测试文件失败: %v", err)
+	}
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("解析multipart请求失败: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Errorf("读取multipart part失败: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		got, err := io.ReadAll(part)
+		if err != nil {
+			t.Errorf("读取文件内容失败: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if string(got) != string(content) {
+			t.Errorf("重试后收到的文件内容不一致，期望%q实际%q", content, got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL, WithTokenProvider(staticTokenProvider("t")))
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	statusCode, err := client.PostFile("/upload", "file", "upload.txt", filePath, nil, &v)
+	if err != nil {
+		t.Fatalf("上传文件失败: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Fatalf("期望状态码200，实际为%d", statusCode)
+	}
+	if atomic.LoadInt32(&attempts) != 2 {
+		t.Fatalf("期望服务端收到2次请求（含401重试），实际为%d", attempts)
+	}
+}
+
+type staticTokenProvider string
+
+func (s staticTokenProvider) Token() (string, time.Time, error) {
+	return string(s), time.Time{}, nil
+}
@@ -0,0 +1,99 @@
+package aipclient
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option 是配置HTTPClient的函数式选项
+type Option func(*HTTPClient)
+
+// WithTimeout 设置底层http.Client的超时时间
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *HTTPClient) {
+		c.client.Timeout = timeout
+	}
+}
+
+// WithHTTPClient 使用调用方提供的http.Client替换默认的http.Client，适用于需要完全自定义Transport的场景。
+// client为nil时忽略该选项
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *HTTPClient) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
+
+// cloneTransport 获取当前http.Client的*http.Transport以便在其基础上修改，如果不是*http.Transport类型则新建一个
+func cloneTransport(client *http.Client) *http.Transport {
+	if transport, ok := client.Transport.(*http.Transport); ok && transport != nil {
+		return transport.Clone()
+	}
+	return &http.Transport{}
+}
+
+// WithTLSConfig 设置请求使用的TLS配置。旧版本中Transport固定开启InsecureSkipVerify，存在安全隐患，
+// 默认已经不再跳过证书校验，如确有需要（例如自签名证书的测试环境）可以通过该选项显式传入
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *HTTPClient) {
+		transport := cloneTransport(c.client)
+		transport.TLSClientConfig = cfg
+		c.client.Transport = transport
+	}
+}
+
+// WithProxy 设置请求经过的代理地址
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *HTTPClient) {
+		transport := cloneTransport(c.client)
+		transport.Proxy = http.ProxyURL(proxyURL)
+		c.client.Transport = transport
+	}
+}
+
+// WithBaseHeaders 设置每个请求都会携带的基础请求头（例如自定义的User-Agent、租户标识等）。
+// 如果请求自身已经设置了同名请求头（例如Post/Put默认设置的Content-Type），基础请求头会覆盖它，
+// 而不是在原有值的基础上追加，避免同一个请求头出现多个值
+func WithBaseHeaders(headers http.Header) Option {
+	return func(c *HTTPClient) {
+		c.baseHeaders = headers.Clone()
+	}
+}
+
+// WithUnauthorizedCodes 自定义触发Token刷新并重试一次的"未授权"状态码集合，默认只有401。
+// 例如某些网关会用403表示Token过期，可以通过该选项把403也纳入进来
+func WithUnauthorizedCodes(codes []int) Option {
+	return func(c *HTTPClient) {
+		c.unauthorizedCodes = append([]int(nil), codes...)
+	}
+}
+
+// WithTokenProvider 使用调用方提供的TokenProvider进行鉴权，取代内置的用户名密码认证方式
+func WithTokenProvider(provider TokenProvider) Option {
+	return func(c *HTTPClient) {
+		c.tokenProvider = provider
+	}
+}
+
+// WithRetryStatusCodes 自定义触发重试的响应状态码集合，默认是429、502、503、504。
+// 只对幂等请求（GET/PUT/DELETE）生效，需要配合WithRetry设置重试次数才会真正触发重试
+func WithRetryStatusCodes(codes []int) Option {
+	return func(c *HTTPClient) {
+		c.retryStatusCodes = append([]int(nil), codes...)
+	}
+}
+
+// WithRetry 开启重试：maxAttempts为幂等请求（GET/PUT/DELETE）失败后最多重试的次数，
+// backoff用于计算每次重试前的等待时间，为nil时沿用默认的指数退避策略。
+// 重试只会在响应状态码属于retryStatusCodes（默认429、502、503、504）时触发，并优先遵循响应的Retry-After
+func WithRetry(maxAttempts int, backoff BackoffFunc) Option {
+	return func(c *HTTPClient) {
+		c.maxRetries = maxAttempts
+		if backoff != nil {
+			c.backoff = backoff
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package aipclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestDefaultTransportVerifiesCertificates 验证默认Transport不再像历史版本那样固定开启
+// InsecureSkipVerify：未显式信任测试服务端证书时请求应因证书校验失败而报错
+func TestDefaultTransportVerifiesCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	_, err = client.Get("/", nil, &v)
+	if err == nil {
+		t.Fatal("期望默认Transport因证书未受信任而报错，实际没有错误")
+	}
+	if !strings.Contains(err.Error(), "x509") {
+		t.Fatalf("期望错误是证书校验失败（包含x509），实际err: %v", err)
+	}
+}
+
+// TestWithTLSConfigTrustsProvidedCA 验证通过WithTLSConfig显式传入测试CA后，请求可以正常完成，
+// 说明默认的证书校验确实生效，而不是被悄悄跳过了
+func TestWithTLSConfigTrustsProvidedCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	client, err := NewHTTPClientWithOptions(server.URL, WithTLSConfig(&tls.Config{RootCAs: pool}))
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	statusCode, err := client.Get("/", nil, &v)
+	if err != nil {
+		t.Fatalf("信任测试CA后请求不应再报证书错误: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Fatalf("期望状态码200，实际为%d", statusCode)
+	}
+}
+
+// TestWithBaseHeadersOverridesRequestContentType 验证基础请求头会覆盖请求自身已经设置的同名请求头
+// （例如Post/Put默认设置的Content-Type），而不是在原有值后面追加出多个值
+func TestWithBaseHeadersOverridesRequestContentType(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Values("Content-Type")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL,
+		WithBaseHeaders(http.Header{"Content-Type": {"application/vnd.api+json"}}),
+	)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	if _, err := client.Post("/", []byte(`{}`), &v); err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+
+	if len(got) != 1 || got[0] != "application/vnd.api+json" {
+		t.Fatalf("期望Content-Type被基础请求头覆盖为单个值application/vnd.api+json，实际为%v", got)
+	}
+}
@@ -0,0 +1,69 @@
+package aipclient
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRetryStatusCodes 是默认可重试的响应状态码集合
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// BackoffFunc 根据重试次数（从1开始）计算下一次重试前的等待时间
+type BackoffFunc func(attempt int) time.Duration
+
+// ExponentialBackoff 返回一个指数退避并带随机抖动的BackoffFunc，base为第一次重试的基准等待时间
+func ExponentialBackoff(base time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		d := base * time.Duration(int64(1)<<uint(attempt-1))
+		jitter := time.Duration(rand.Int63n(int64(d)/2 + 1))
+		return d + jitter
+	}
+}
+
+// isIdempotentMethod 判断请求方法是否是可以安全重试的幂等方法
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryable 判断响应状态码是否属于可重试的状态码集合
+func (c *HTTPClient) isRetryable(statusCode int) bool {
+	for _, code := range c.retryStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// retryWait 计算下一次重试前需要等待的时间：优先遵循响应中的Retry-After，否则使用配置的退避策略
+func (c *HTTPClient) retryWait(attempt int, header http.Header) time.Duration {
+	if header != nil {
+		if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+			if secs, err := strconv.Atoi(retryAfter); err == nil {
+				return time.Duration(secs) * time.Second
+			}
+			if when, err := http.ParseTime(retryAfter); err == nil {
+				if d := time.Until(when); d > 0 {
+					return d
+				}
+			}
+		}
+	}
+
+	if c.backoff != nil {
+		return c.backoff(attempt)
+	}
+	return 0
+}
@@ -0,0 +1,127 @@
+package aipclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRetryStatusCodesOverridesDefault(t *testing.T) {
+	c := &HTTPClient{retryStatusCodes: defaultRetryStatusCodes}
+	WithRetryStatusCodes([]int{418})(c)
+
+	if !c.isRetryable(418) {
+		t.Fatal("配置418为可重试状态码后，isRetryable(418)应返回true")
+	}
+	if c.isRetryable(429) {
+		t.Fatal("覆盖后默认的429不应再被视为可重试")
+	}
+}
+
+// TestGetRetriesUntilSuccess 端到端验证doRequest的重试循环：服务端先返回两次503，
+// 第三次才返回200，期望客户端透明地重试并最终拿到成功结果
+func TestGetRetriesUntilSuccess(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL, WithRetry(3, ExponentialBackoff(time.Millisecond)))
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	statusCode, err := client.Get("/", nil, &v)
+	if err != nil {
+		t.Fatalf("期望重试后最终成功，实际返回错误: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Fatalf("期望状态码200，实际为%d", statusCode)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("期望服务端收到3次请求（2次503+1次200），实际为%d", got)
+	}
+}
+
+// TestGetHonorsRetryAfterSeconds 验证响应携带Retry-After（秒数形式）时，
+// 重试等待时间以Retry-After为准，而不是配置的退避策略
+func TestGetHonorsRetryAfterSeconds(t *testing.T) {
+	var attempts int32
+	var firstAttempt, secondAttempt time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			firstAttempt = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		secondAttempt = time.Now()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL, WithRetry(1, ExponentialBackoff(time.Hour)))
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	if _, err := client.Get("/", nil, &v); err != nil {
+		t.Fatalf("期望重试后最终成功，实际返回错误: %v", err)
+	}
+
+	wait := secondAttempt.Sub(firstAttempt)
+	if wait < 900*time.Millisecond {
+		t.Fatalf("期望按Retry-After等待约1秒，实际只等待了%s", wait)
+	}
+	if wait > 10*time.Second {
+		t.Fatalf("期望按Retry-After等待约1秒，实际等待了%s（疑似退回到了配置的退避策略）", wait)
+	}
+}
+
+// TestGetHonorsRetryAfterHTTPDate 验证响应携带Retry-After（HTTP-date形式）时也能被正确解析
+func TestGetHonorsRetryAfterHTTPDate(t *testing.T) {
+	var attempts int32
+	var firstAttempt, secondAttempt time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			firstAttempt = time.Now()
+			// HTTP-date只有秒级精度，这里预留2秒余量，避免格式化时的截断让剩余等待时间小于1秒
+			w.Header().Set("Retry-After", time.Now().Add(2*time.Second).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		secondAttempt = time.Now()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL, WithRetry(1, ExponentialBackoff(time.Hour)))
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	if _, err := client.Get("/", nil, &v); err != nil {
+		t.Fatalf("期望重试后最终成功，实际返回错误: %v", err)
+	}
+
+	wait := secondAttempt.Sub(firstAttempt)
+	if wait < time.Second {
+		t.Fatalf("期望按Retry-After（HTTP-date）等待约2秒，实际只等待了%s", wait)
+	}
+	if wait > 10*time.Second {
+		t.Fatalf("期望按Retry-After（HTTP-date）等待约2秒，实际等待了%s（疑似退回到了配置的退避策略）", wait)
+	}
+}
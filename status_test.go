@@ -0,0 +1,151 @@
+package aipclient
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecodeResponseEmptyBody(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+
+	if err := decodeResponse(http.StatusNoContent, nil, &v); err != nil {
+		t.Fatalf("decodeResponse应忽略空响应体，却返回了错误: %v", err)
+	}
+
+	if err := decodeResponse(http.StatusNoContent, []byte{}, &v); err != nil {
+		t.Fatalf("decodeResponse应忽略空响应体，却返回了错误: %v", err)
+	}
+
+	if err := decodeResponse(http.StatusOK, []byte(`{"name":"ok"}`), &v); err != nil {
+		t.Fatalf("非空响应体解析失败: %v", err)
+	}
+	if v.Name != "ok" {
+		t.Fatalf("期望Name为ok，实际为%q", v.Name)
+	}
+}
+
+func TestGetSucceedsOnEmptyBody204(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	statusCode, err := client.Get("/", nil, &v)
+	if err != nil {
+		t.Fatalf("204且响应体为空时不应报错，实际返回: %v", err)
+	}
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("期望状态码204，实际为%d", statusCode)
+	}
+}
+
+func TestRequestErrorStatusHelpers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"not_found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var v struct{}
+	_, err = client.Get("/", nil, &v)
+	if err == nil {
+		t.Fatal("期望404返回错误，实际没有错误")
+	}
+	if !IsNotFound(err) {
+		t.Fatalf("期望IsNotFound为true，实际err: %v", err)
+	}
+	if IsUnauthorized(err) {
+		t.Fatal("期望IsUnauthorized为false")
+	}
+
+	var apiErr struct {
+		Code string `json:"code"`
+	}
+	reqErr, ok := err.(*RequestError)
+	if !ok {
+		t.Fatalf("期望err为*RequestError，实际为%T", err)
+	}
+	if decodeErr := reqErr.Decode(&apiErr); decodeErr != nil {
+		t.Fatalf("解析错误响应体失败: %v", decodeErr)
+	}
+	if apiErr.Code != "not_found" {
+		t.Fatalf("期望code为not_found，实际为%q", apiErr.Code)
+	}
+}
+
+func TestGetWritesBodyToIOWriter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("raw-file-content"))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	var buf bytes.Buffer
+	statusCode, err := client.Get("/download", nil, &buf)
+	if err != nil {
+		t.Fatalf("下载失败: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Fatalf("期望状态码200，实际为%d", statusCode)
+	}
+	if buf.String() != "raw-file-content" {
+		t.Fatalf("期望写入io.Writer的内容为raw-file-content，实际为%q", buf.String())
+	}
+}
+
+// recordingDecoder 实现ResponseDecoder，用于验证decodeResponse优先走自定义解析而非json.Unmarshal
+type recordingDecoder struct {
+	statusCode int
+	body       []byte
+}
+
+func (d *recordingDecoder) DecodeResponse(statusCode int, body []byte) error {
+	d.statusCode = statusCode
+	d.body = body
+	return nil
+}
+
+func TestGetUsesCustomResponseDecoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("not-json-at-all"))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(server.URL)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	decoder := &recordingDecoder{}
+	statusCode, err := client.Get("/", nil, decoder)
+	if err != nil {
+		t.Fatalf("请求失败，ResponseDecoder不应触发json.Unmarshal错误: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Fatalf("期望状态码200，实际为%d", statusCode)
+	}
+	if decoder.statusCode != http.StatusOK || string(decoder.body) != "not-json-at-all" {
+		t.Fatalf("期望ResponseDecoder收到状态码200和响应体not-json-at-all，实际状态码%d，响应体%q", decoder.statusCode, decoder.body)
+	}
+}